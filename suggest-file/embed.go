@@ -8,6 +8,12 @@ var bashWrapper string
 //go:embed suggest-file.zsh
 var zshWrapper string
 
+//go:embed suggest-file.fish
+var fishWrapper string
+
+//go:embed suggest-file.ps1
+var pwshWrapper string
+
 // shellWrapper returns the embedded shell wrapper script for the given shell
 // name. The second return value is false if the shell is not recognised.
 func shellWrapper(shell string) (string, bool) {
@@ -16,6 +22,10 @@ func shellWrapper(shell string) (string, bool) {
 		return bashWrapper, true
 	case "zsh":
 		return zshWrapper, true
+	case "fish":
+		return fishWrapper, true
+	case "pwsh":
+		return pwshWrapper, true
 	default:
 		return "", false
 	}
@@ -22,22 +22,31 @@
 // includes hidden files within visible directories. When using explicit
 // glob patterns, hidden entries must be targeted via patterns like '.*' or
 // '.config/**'.
+//
+// Steps 2, 3, 5 and 6 filter their results through a walker.Options: its
+// Matcher applies -x/--exclude and -I/--include, and its Ignore chain applies
+// gitignore rules. An existing regular file named directly (step 4) is
+// returned as given, without filtering.
 package glob
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/bmatcuk/doublestar/v4"
 
+	"github.com/Crystalix007/cli-tools/suggest-file/ignore"
 	"github.com/Crystalix007/cli-tools/suggest-file/walker"
 )
 
 // Expand takes an argument string and returns all matching file paths.
-// See package documentation for the full resolution logic.
-func Expand(pattern string) ([]string, error) {
+// See package documentation for the full resolution logic. opts filters
+// the results via opts.Matcher and opts.Ignore on every resolution path
+// except an exact file match, which is returned as given.
+func Expand(pattern string, opts walker.Options) ([]string, error) {
 	// Step 1: Expand tilde.
 	expanded, err := expandTilde(pattern)
 	if err != nil {
@@ -50,7 +59,7 @@ func Expand(pattern string) ([]string, error) {
 
 	// Step 2: If the pattern contains glob metacharacters, use doublestar.
 	if containsMeta(expanded) {
-		return expandGlob(expanded)
+		return expandGlob(expanded, opts)
 	}
 
 	// Clean the path for filesystem operations, but only after checking
@@ -59,14 +68,14 @@ func Expand(pattern string) ([]string, error) {
 
 	// Step 3: Trailing slash → list files in that directory (one level).
 	if trailingSlash {
-		return walker.ListDir(cleaned)
+		return walker.ListDir(cleaned, opts)
 	}
 
 	// Step 4/5: Stat the path to determine if it's a file or directory.
 	info, err := os.Stat(cleaned)
 	if err == nil {
 		if info.IsDir() {
-			return walker.WalkCollect(cleaned)
+			return walker.WalkCollect(cleaned, opts)
 		}
 		if info.Mode().IsRegular() {
 			// Exact file match — return it directly.
@@ -79,37 +88,77 @@ func Expand(pattern string) ([]string, error) {
 	}
 
 	// Step 6: Prefix match — treat the last component as a prefix.
-	return expandPrefix(cleaned)
+	return expandPrefix(cleaned, opts)
 }
 
 // expandGlob performs doublestar glob expansion on a pattern that contains
 // metacharacters. Only regular files (and symlinks resolving to regular files)
-// are included in the results.
-func expandGlob(pattern string) ([]string, error) {
+// are included in the results. A directory the pattern itself matches is not
+// descended into when opts.Matcher excludes it or opts.Ignore ignores it.
+// Other directories are still read by GlobWalk; a file found in one is
+// dropped when any directory between the pattern's base and the file is
+// excluded or ignored.
+func expandGlob(pattern string, opts walker.Options) ([]string, error) {
 	// Split the pattern into a base directory and the glob portion.
 	// This allows doublestar to work correctly with absolute and relative paths.
 	base, globPart := splitPattern(pattern)
 
 	fsys := os.DirFS(base)
-	matches, err := doublestar.Glob(fsys, globPart)
-	if err != nil {
-		return nil, fmt.Errorf("glob %q: %w", pattern, err)
+
+	var results []string
+
+	// chains caches the ignore.Chain for each directory encountered, keyed
+	// by full path, so each directory's .gitignore is loaded once. GlobWalk
+	// only calls back for paths the pattern matches, so chainFor builds any
+	// missing ancestor chains on demand.
+	chains := map[string]*ignore.Chain{base: opts.Ignore.Child(base)}
+
+	var chainFor func(dir string) *ignore.Chain
+	chainFor = func(dir string) *ignore.Chain {
+		if c, ok := chains[dir]; ok {
+			return c
+		}
+		c := chainFor(filepath.Dir(dir)).Child(dir)
+		chains[dir] = c
+		return c
 	}
 
-	// Reconstruct full paths by joining the base back.
-	results := make([]string, 0, len(matches))
-	for _, m := range matches {
-		full := filepath.Join(base, m)
+	err := doublestar.GlobWalk(fsys, globPart, func(relPath string, d fs.DirEntry) error {
+		full := filepath.Join(base, relPath)
+
+		if d.IsDir() {
+			chain := chainFor(full)
+			if opts.Matcher.Excluded(full) || chain.Match(full, true) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 
-		info, err := os.Lstat(full)
+		info, err := d.Info()
 		if err != nil {
-			// Skip files we cannot stat (e.g. permission denied).
-			continue
+			// Skip entries we cannot stat (e.g. permission denied).
+			return nil
 		}
 
-		if walker.IsIncludableFile(full, info.Mode()) {
-			results = append(results, full)
+		if !walker.IsIncludableFile(full, info.Mode()) || !opts.Matcher.Allows(full) ||
+			chainFor(filepath.Dir(full)).Match(full, false) {
+			return nil
+		}
+
+		// Directories the pattern doesn't match never reach the d.IsDir()
+		// check above, so check the file's ancestors below base here.
+		for dir := filepath.Dir(full); dir != base && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+			if opts.Matcher.Excluded(dir) || chainFor(dir).Match(dir, true) {
+				return nil
+			}
 		}
+
+		results = append(results, full)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("glob %q: %w", pattern, err)
 	}
 
 	return results, nil
@@ -117,8 +166,9 @@ func expandGlob(pattern string) ([]string, error) {
 
 // expandPrefix treats the last component of path as a prefix and finds all
 // entries in the parent directory that start with it. Matching directories
-// are walked recursively; matching regular files are included directly.
-func expandPrefix(path string) ([]string, error) {
+// are walked recursively (unless excluded by opts.Matcher); matching
+// regular files are included directly.
+func expandPrefix(path string, opts walker.Options) ([]string, error) {
 	dir := filepath.Dir(path)
 	prefix := filepath.Base(path)
 
@@ -127,6 +177,8 @@ func expandPrefix(path string) ([]string, error) {
 		return nil, fmt.Errorf("reading directory %q: %w", dir, err)
 	}
 
+	chain := opts.Ignore.Child(dir)
+
 	var results []string
 
 	for _, e := range entries {
@@ -136,22 +188,30 @@ func expandPrefix(path string) ([]string, error) {
 
 		full := filepath.Join(dir, e.Name())
 
-		// Check if this is a directory (resolving symlinks).
-		isDir := false
-		if e.Type()&os.ModeSymlink != 0 {
+		// A symlinked directory only counts as a directory when
+		// opts.FollowSymlinks is set; otherwise it's treated as a leaf,
+		// consistent with walker.WalkConcurrent.
+		isDir := e.IsDir()
+		if !isDir && opts.FollowSymlinks && e.Type()&os.ModeSymlink != 0 {
 			resolved, err := os.Stat(full)
 			if err != nil {
 				// Dangling symlink — skip.
 				continue
 			}
 			isDir = resolved.IsDir()
-		} else {
-			isDir = e.IsDir()
 		}
 
 		if isDir {
-			// Directory matching prefix — walk recursively.
-			collected, err := walker.WalkCollect(full)
+			if opts.Matcher.Excluded(full) || chain.Match(full, true) {
+				continue
+			}
+
+			// Directory matching prefix — walk recursively, carrying this
+			// directory's ignore chain down so nested .gitignore files keep
+			// layering correctly.
+			subOpts := opts
+			subOpts.Ignore = chain
+			collected, err := walker.WalkCollect(full, subOpts)
 			if err != nil {
 				return nil, err
 			}
@@ -159,7 +219,7 @@ func expandPrefix(path string) ([]string, error) {
 			continue
 		}
 
-		if walker.IsIncludableFile(full, e.Type()) {
+		if walker.IsIncludableFile(full, e.Type()) && opts.Matcher.Allows(full) && !chain.Match(full, false) {
 			results = append(results, full)
 		}
 	}
@@ -0,0 +1,182 @@
+package glob
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Crystalix007/cli-tools/suggest-file/ignore"
+	"github.com/Crystalix007/cli-tools/suggest-file/walker"
+)
+
+func writeFile(t *testing.T, path string, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// buildTree lays out:
+//
+//	root/
+//	  .gitignore       (excludes secret.txt)
+//	  visible.txt
+//	  secret.txt
+//	  sub/
+//	    secret.txt
+//	    other.txt
+func buildTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+
+	writeFile(t, filepath.Join(root, ".gitignore"), "secret.txt\n")
+	writeFile(t, filepath.Join(root, "visible.txt"), "")
+	writeFile(t, filepath.Join(root, "secret.txt"), "")
+
+	if err := os.Mkdir(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(root, "sub", "secret.txt"), "")
+	writeFile(t, filepath.Join(root, "sub", "other.txt"), "")
+
+	return root
+}
+
+func names(paths []string) []string {
+	out := make([]string, len(paths))
+	for i, p := range paths {
+		out[i] = filepath.Base(p)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestSplitPattern(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		wantBase string
+		wantGlob string
+	}{
+		{"*.go", ".", "*.go"},
+		{"pkg/*.go", "pkg", "*.go"},
+		{"pkg/sub/**/*.go", "pkg/sub", "**/*.go"},
+		{"/abs/pkg/*.go", "/abs/pkg", "*.go"},
+		{"/*.go", "/", "*.go"},
+	}
+
+	for _, c := range cases {
+		base, glob := splitPattern(c.pattern)
+		if base != c.wantBase || glob != c.wantGlob {
+			t.Errorf("splitPattern(%q) = (%q, %q), want (%q, %q)", c.pattern, base, glob, c.wantBase, c.wantGlob)
+		}
+	}
+}
+
+func TestExpandGlobHonorsGitignore(t *testing.T) {
+	root := buildTree(t)
+
+	opts := walker.Options{Ignore: ignore.NewChain(root, nil)}
+	got, err := expandGlob(filepath.Join(root, "**/*.txt"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"other.txt", "visible.txt"}; !equal(names(got), want) {
+		t.Errorf("got %v, want %v", names(got), want)
+	}
+}
+
+func TestExpandGlobNilIgnoreDisablesGitignore(t *testing.T) {
+	root := buildTree(t)
+
+	// opts.Ignore is nil, equivalent to --no-ignore: every file should be
+	// returned, including the secret.txt files the root .gitignore excludes.
+	got, err := expandGlob(filepath.Join(root, "**/*.txt"), walker.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"other.txt", "secret.txt", "secret.txt", "visible.txt"}
+	if !equal(names(got), want) {
+		t.Errorf("got %v, want %v", names(got), want)
+	}
+}
+
+func TestExpandPrefixHonorsGitignore(t *testing.T) {
+	root := buildTree(t)
+
+	opts := walker.Options{Ignore: ignore.NewChain(root, nil)}
+	got, err := expandPrefix(filepath.Join(root, "s"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// "s" prefix-matches secret.txt (excluded) and sub/ (walked recursively,
+	// its own secret.txt also excluded, other.txt kept).
+	if want := []string{"other.txt"}; !equal(names(got), want) {
+		t.Errorf("got %v, want %v", names(got), want)
+	}
+}
+
+func TestExpandPrefixNilIgnoreDisablesGitignore(t *testing.T) {
+	root := buildTree(t)
+
+	got, err := expandPrefix(filepath.Join(root, "s"), walker.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"other.txt", "secret.txt", "secret.txt"}
+	if !equal(names(got), want) {
+		t.Errorf("got %v, want %v", names(got), want)
+	}
+}
+
+func TestExpandResolvesDirectoryAndFile(t *testing.T) {
+	root := buildTree(t)
+
+	file, err := Expand(filepath.Join(root, "visible.txt"), walker.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file) != 1 || filepath.Base(file[0]) != "visible.txt" {
+		t.Fatalf("got %v, want exactly visible.txt", file)
+	}
+
+	dir, err := Expand(root, walker.Options{Ignore: ignore.NewChain(root, nil)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Hidden files (like .gitignore itself) are included within visible
+	// directories, per the walker package's own semantics.
+	if want := []string{".gitignore", "other.txt", "visible.txt"}; !equal(names(dir), want) {
+		t.Errorf("got %v, want %v", names(dir), want)
+	}
+}
+
+func TestExpandTrailingSlashListsOneLevel(t *testing.T) {
+	root := buildTree(t)
+
+	got, err := Expand(root+string(filepath.Separator), walker.Options{Ignore: ignore.NewChain(root, nil)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One level deep only: sub/other.txt must not appear.
+	if want := []string{".gitignore", "visible.txt"}; !equal(names(got), want) {
+		t.Errorf("got %v, want %v", names(got), want)
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
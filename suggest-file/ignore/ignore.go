@@ -0,0 +1,194 @@
+// Package ignore parses .gitignore-syntax files and evaluates them against
+// candidate paths, so suggest-file can skip node_modules, target/, build
+// artifacts, and anything else a repo has already told git to ignore.
+//
+// Ignore files are consulted hierarchically: descending into a directory
+// layers that directory's own .gitignore on top of its parent's via Chain,
+// so a child directory's rules are evaluated — and so can override — the
+// rules inherited from above, matching git's own semantics.
+package ignore
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// Pattern is a single parsed line from a gitignore-syntax file.
+type Pattern struct {
+	// negate is true for '!'-prefixed patterns, which re-include a path
+	// that an earlier pattern excluded.
+	negate bool
+	// dirOnly is true for patterns with a trailing '/', which only match
+	// directories.
+	dirOnly bool
+	// anchored is true for patterns containing a '/' before the end (a
+	// leading '/' or one in the middle), which are rooted to the directory
+	// that defined them rather than matching at any depth.
+	anchored bool
+	// glob is the doublestar-compatible pattern itself, with any leading
+	// or trailing '/' already stripped.
+	glob string
+}
+
+// ParsePatterns parses the lines of a gitignore-syntax file. Blank lines and
+// comments ('#'-prefixed) are skipped; a literal leading '#' or '!' can be
+// matched by escaping it with a backslash.
+func ParsePatterns(lines []string) []Pattern {
+	var patterns []Pattern
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		negate := false
+		switch {
+		case strings.HasPrefix(line, "!"):
+			negate = true
+			line = line[1:]
+		case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+			line = line[1:]
+		}
+
+		dirOnly := strings.HasSuffix(line, "/")
+		line = strings.TrimSuffix(line, "/")
+
+		if line == "" {
+			continue
+		}
+
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+
+		if line == "" {
+			continue
+		}
+
+		patterns = append(patterns, Pattern{
+			negate:   negate,
+			dirOnly:  dirOnly,
+			anchored: anchored,
+			glob:     line,
+		})
+	}
+
+	return patterns
+}
+
+// Load reads and parses a gitignore-syntax file at path.
+func Load(filePath string) ([]Pattern, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatterns(strings.Split(string(data), "\n")), nil
+}
+
+// match reports whether rel (a '/'-separated path relative to the directory
+// that owns this pattern) is matched by p. isDir indicates whether rel names
+// a directory, since dirOnly patterns only match directories.
+func (p Pattern) match(rel string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	if p.anchored {
+		if ok, _ := doublestar.Match(p.glob, rel); ok {
+			return true
+		}
+		// An anchored directory pattern also covers everything beneath it.
+		ok, _ := doublestar.Match(p.glob+"/**", rel)
+		return ok
+	}
+
+	// Unanchored patterns match the basename, or the pattern rooted at any
+	// depth, so "node_modules" excludes it wherever it appears.
+	if ok, _ := doublestar.Match(p.glob, path.Base(rel)); ok {
+		return true
+	}
+	if ok, _ := doublestar.Match("**/"+p.glob, rel); ok {
+		return true
+	}
+	ok, _ := doublestar.Match("**/"+p.glob+"/**", rel)
+	return ok
+}
+
+// Chain is an immutable, hierarchical stack of ignore rules rooted at a
+// directory. Each directory boundary pushes a new Chain via Child, layering
+// that directory's own .gitignore on top of the parent chain.
+type Chain struct {
+	parent   *Chain
+	dir      string
+	patterns []Pattern
+}
+
+// NewChain builds the root of an ignore chain rooted at dir, seeded with
+// patterns loaded ahead of time (e.g. from the global
+// ~/.config/suggest-file/ignore file or --ignore-file).
+func NewChain(dir string, patterns []Pattern) *Chain {
+	return &Chain{dir: dir, patterns: patterns}
+}
+
+// Child returns a new Chain scoped to subdirectory dir, incorporating dir's
+// own .gitignore if one exists. dir need not be an immediate child of c's
+// directory, but it must be nested under it. A nil c (ignoring disabled)
+// stays nil, rather than resurrecting a live chain from dir's own
+// .gitignore alone.
+func (c *Chain) Child(dir string) *Chain {
+	if c == nil {
+		return nil
+	}
+
+	patterns, err := Load(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		patterns = nil
+	}
+	return &Chain{parent: c, dir: dir, patterns: patterns}
+}
+
+// Match reports whether path (absolute or relative; it need not match the
+// style the chain's directories were built from) should be ignored. isDir
+// indicates whether path names a directory.
+//
+// Per gitignore semantics, the most specific chain's patterns are checked
+// first, most-recently-defined pattern first within a file; the first
+// pattern that matches anywhere in the chain decides the outcome, honoring
+// negation. If c is nil, nothing is ignored.
+func (c *Chain) Match(matchPath string, isDir bool) bool {
+	// filepath.Rel errors if one side is absolute and the other relative,
+	// so resolve both to absolute paths before comparing: otherwise a chain
+	// rooted with a relative dir (e.g. ".") would silently never match
+	// absolute paths produced by walking an absolute argument, or vice
+	// versa.
+	absMatch, err := filepath.Abs(matchPath)
+	if err != nil {
+		return false
+	}
+
+	for chain := c; chain != nil; chain = chain.parent {
+		dir, err := filepath.Abs(chain.dir)
+		if err != nil {
+			continue
+		}
+
+		rel, err := filepath.Rel(dir, absMatch)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+
+		for i := len(chain.patterns) - 1; i >= 0; i-- {
+			p := chain.patterns[i]
+			if p.match(rel, isDir) {
+				return !p.negate
+			}
+		}
+	}
+
+	return false
+}
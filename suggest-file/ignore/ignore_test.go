@@ -0,0 +1,105 @@
+package ignore
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePatterns(t *testing.T) {
+	patterns := ParsePatterns([]string{
+		"# a comment",
+		"",
+		"*.log",
+		"!keep.log",
+		"build/",
+		"/rooted.txt",
+		`\#literal`,
+	})
+
+	if len(patterns) != 5 {
+		t.Fatalf("got %d patterns, want 5: %+v", len(patterns), patterns)
+	}
+
+	if patterns[1].negate != true || patterns[1].glob != "keep.log" {
+		t.Errorf("negated pattern parsed as %+v", patterns[1])
+	}
+	if !patterns[2].dirOnly || patterns[2].glob != "build" {
+		t.Errorf("dir-only pattern parsed as %+v", patterns[2])
+	}
+	if !patterns[3].anchored || patterns[3].glob != "rooted.txt" {
+		t.Errorf("anchored pattern parsed as %+v", patterns[3])
+	}
+	if patterns[4].glob != "#literal" {
+		t.Errorf("escaped pattern parsed as %+v", patterns[4])
+	}
+}
+
+func TestChainMatch(t *testing.T) {
+	root := t.TempDir()
+
+	chain := NewChain(root, ParsePatterns([]string{
+		"*.log",
+		"!important.log",
+		"build/",
+		"/rooted.txt",
+	}))
+
+	cases := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"debug.log", false, true},
+		{"important.log", false, false},
+		{"nested/debug.log", false, true},
+		{"build", true, true},
+		{"build", false, false}, // dirOnly: only matches as a directory
+		{"rooted.txt", false, true},
+		{"nested/rooted.txt", false, false}, // anchored: only matches at root
+	}
+
+	for _, c := range cases {
+		got := chain.Match(filepath.Join(root, c.path), c.isDir)
+		if got != c.want {
+			t.Errorf("Match(%q, isDir=%v) = %v, want %v", c.path, c.isDir, got, c.want)
+		}
+	}
+}
+
+func TestChainMatchAbsoluteAndRelative(t *testing.T) {
+	chain := NewChain(".", ParsePatterns([]string{"secret.txt"}))
+
+	abs, err := filepath.Abs("secret.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !chain.Match("secret.txt", false) {
+		t.Error("relative match path: want ignored")
+	}
+	if !chain.Match(abs, false) {
+		t.Error("absolute match path: want ignored")
+	}
+}
+
+func TestChainChildOverridesParent(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+
+	parent := NewChain(root, ParsePatterns([]string{"*.log"}))
+	child := &Chain{parent: parent, dir: sub, patterns: ParsePatterns([]string{"!keep.log"})}
+
+	if child.Match(filepath.Join(sub, "keep.log"), false) {
+		t.Error("child negation should override parent exclude")
+	}
+	if !child.Match(filepath.Join(sub, "other.log"), false) {
+		t.Error("parent pattern should still apply through child")
+	}
+}
+
+func TestChainNilMatchesNothing(t *testing.T) {
+	var chain *Chain
+	if chain.Match("anything", false) {
+		t.Error("nil chain should never match")
+	}
+}
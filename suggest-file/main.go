@@ -6,23 +6,37 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/Crystalix007/cli-tools/suggest-file/glob"
+	"github.com/Crystalix007/cli-tools/suggest-file/ignore"
 	"github.com/Crystalix007/cli-tools/suggest-file/walker"
 )
 
+// globalIgnoreFile is the user-wide ignore file consulted unless --no-ignore
+// is passed, analogous to git's core.excludesFile.
+const globalIgnoreFile = "suggest-file/ignore"
+
 func main() {
 	args := os.Args[1:]
 
 	// Handle help flag.
 	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
-		fmt.Println("Usage: suggest-file [PATTERN ...]")
-		fmt.Println("       suggest-file --shell bash|zsh")
+		fmt.Println("Usage: suggest-file [OPTIONS] [PATTERN ...]")
+		fmt.Println("       suggest-file --shell bash|zsh|fish|pwsh")
 		fmt.Println("List files matching patterns. With no arguments, list all files recursively.")
 		fmt.Println("")
 		fmt.Println("Options:")
-		fmt.Println("  --shell bash|zsh  Enable suggest-file completion in your shell.")
-		fmt.Println("                    Prints a snippet to stdout; source it in your rc file.")
+		fmt.Println("  --shell bash|zsh|fish|pwsh  Enable suggest-file completion in your shell.")
+		fmt.Println("                          Prints a snippet to stdout; source it in your rc file.")
+		fmt.Println("  -x, --exclude PATTERN   Exclude paths matching PATTERN (doublestar syntax,")
+		fmt.Println("                          repeatable). Matched directories are pruned entirely.")
+		fmt.Println("  -I, --include PATTERN   Only include paths matching PATTERN (repeatable).")
+		fmt.Println("  --no-default-excludes   Don't skip .git and editor swap files by default.")
+		fmt.Println("  --ignore-file PATH      Also honor gitignore-syntax rules from PATH (repeatable).")
+		fmt.Println("  --no-ignore             Don't honor .gitignore files or the global ignore file.")
+		fmt.Println("  -0, --print0            Terminate each path with NUL instead of newline.")
+		fmt.Println("  -L, --follow            Follow symlinked directories during recursive walks.")
 		fmt.Println("")
 		fmt.Println("Argument resolution:")
 		fmt.Println("  DIRECTORY          Recursively list all files under it (e.g. ~, ~/Downloads, .)")
@@ -46,21 +60,111 @@ func main() {
 	// Handle --shell flag.
 	if len(args) >= 1 && args[0] == "--shell" {
 		if len(args) < 2 {
-			fmt.Fprintln(os.Stderr, "suggest-file: --shell requires an argument (bash or zsh)")
+			fmt.Fprintln(os.Stderr, "suggest-file: --shell requires an argument (bash, zsh, fish, or pwsh)")
 			os.Exit(1)
 		}
 		script, ok := shellWrapper(args[1])
 		if !ok {
-			fmt.Fprintf(os.Stderr, "suggest-file: unknown shell %q (supported: bash, zsh)\n", args[1])
+			fmt.Fprintf(os.Stderr, "suggest-file: unknown shell %q (supported: bash, zsh, fish, pwsh)\n", args[1])
 			os.Exit(1)
 		}
 		fmt.Print(script)
 		return
 	}
 
-	// If no arguments provided, default to recursive listing of the current directory.
-	if len(args) == 0 {
-		if err := walker.Walk("."); err != nil {
+	// Pull out -x/--exclude, -I/--include, --no-default-excludes,
+	// --ignore-file, --no-ignore, -0/--print0 and -L/--follow, leaving the
+	// remaining arguments as patterns to resolve.
+	var includes, excludes, ignoreFiles []string
+	noDefaultExcludes := false
+	noIgnore := false
+	print0 := false
+	followSymlinks := false
+	var patterns []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-x", "--exclude":
+			i++
+			if i >= len(args) {
+				fmt.Fprintf(os.Stderr, "suggest-file: %s requires an argument\n", args[i-1])
+				os.Exit(1)
+			}
+			excludes = append(excludes, args[i])
+		case "-I", "--include":
+			i++
+			if i >= len(args) {
+				fmt.Fprintf(os.Stderr, "suggest-file: %s requires an argument\n", args[i-1])
+				os.Exit(1)
+			}
+			includes = append(includes, args[i])
+		case "--no-default-excludes":
+			noDefaultExcludes = true
+		case "--ignore-file":
+			i++
+			if i >= len(args) {
+				fmt.Fprintf(os.Stderr, "suggest-file: %s requires an argument\n", args[i-1])
+				os.Exit(1)
+			}
+			ignoreFiles = append(ignoreFiles, args[i])
+		case "--no-ignore":
+			noIgnore = true
+		case "-0", "--print0":
+			print0 = true
+		case "-L", "--follow":
+			followSymlinks = true
+		default:
+			patterns = append(patterns, args[i])
+		}
+	}
+
+	if !noDefaultExcludes {
+		excludes = append(append([]string{}, walker.DefaultExcludes...), excludes...)
+	}
+
+	matcher, err := walker.NewMatcher(includes, excludes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "suggest-file: %v\n", err)
+		os.Exit(1)
+	}
+
+	var chain *ignore.Chain
+	if !noIgnore {
+		var ignorePatterns []ignore.Pattern
+		if home, err := os.UserHomeDir(); err == nil {
+			if loaded, err := ignore.Load(filepath.Join(home, ".config", globalIgnoreFile)); err == nil {
+				ignorePatterns = append(ignorePatterns, loaded...)
+			}
+		}
+		for _, f := range ignoreFiles {
+			loaded, err := ignore.Load(f)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "suggest-file: --ignore-file %s: %v\n", f, err)
+				os.Exit(1)
+			}
+			ignorePatterns = append(ignorePatterns, loaded...)
+		}
+		// Root the chain at the working directory: Chain.Match evaluates
+		// these patterns relative to it, and paths outside it are not
+		// matched against them.
+		root, err := filepath.Abs(".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "suggest-file: %v\n", err)
+			os.Exit(1)
+		}
+		chain = ignore.NewChain(root, ignorePatterns)
+	}
+
+	opts := walker.Options{Matcher: matcher, Ignore: chain, Print0: print0, FollowSymlinks: followSymlinks}
+
+	terminator := "\n"
+	if print0 {
+		terminator = "\x00"
+	}
+
+	// If no patterns provided, default to recursive listing of the current directory.
+	if len(patterns) == 0 {
+		if err := walker.Walk(".", opts); err != nil {
 			fmt.Fprintf(os.Stderr, "suggest-file: %v\n", err)
 			os.Exit(1)
 		}
@@ -69,8 +173,8 @@ func main() {
 
 	// Expand each glob pattern and print matching file paths.
 	exitCode := 0
-	for _, pattern := range args {
-		matches, err := glob.Expand(pattern)
+	for _, pattern := range patterns {
+		matches, err := glob.Expand(pattern, opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "suggest-file: %s: %v\n", pattern, err)
 			exitCode = 1
@@ -80,7 +184,7 @@ func main() {
 			fmt.Fprintf(os.Stderr, "suggest-file: %s: no matches\n", pattern)
 		}
 		for _, match := range matches {
-			fmt.Println(match)
+			fmt.Fprintf(os.Stdout, "%s%s", match, terminator)
 		}
 	}
 
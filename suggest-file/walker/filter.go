@@ -0,0 +1,110 @@
+package walker
+
+import (
+	"fmt"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/Crystalix007/cli-tools/suggest-file/ignore"
+)
+
+// DefaultExcludes are pruned from results unless the caller disables them
+// (e.g. via --no-default-excludes): VCS metadata and editor swap files that
+// are essentially never useful completion targets.
+var DefaultExcludes = []string{
+	"**/.git/**",
+	"**/*.swp",
+	"**/*.swo",
+}
+
+// Matcher evaluates a compiled set of include/exclude patterns against full
+// file paths. Patterns use doublestar syntax (*, ?, [a-z], {a,b}, and /**/
+// for recursive matching) and are matched against the full path being
+// considered, not a path relative to the walk root, so a pattern like
+// "**/vendor/**" excludes vendor directories no matter where the walk
+// started. A nil *Matcher matches everything and excludes nothing.
+type Matcher struct {
+	includes []string
+	excludes []string
+}
+
+// NewMatcher compiles includes and excludes into a Matcher. It returns an
+// error if any pattern is not valid doublestar syntax.
+func NewMatcher(includes, excludes []string) (*Matcher, error) {
+	for _, p := range includes {
+		if !doublestar.ValidatePattern(p) {
+			return nil, fmt.Errorf("invalid include pattern %q", p)
+		}
+	}
+
+	for _, p := range excludes {
+		if !doublestar.ValidatePattern(p) {
+			return nil, fmt.Errorf("invalid exclude pattern %q", p)
+		}
+	}
+
+	return &Matcher{includes: includes, excludes: excludes}, nil
+}
+
+// Excluded reports whether path matches any exclude pattern.
+func (m *Matcher) Excluded(path string) bool {
+	if m == nil {
+		return false
+	}
+	return anyMatch(m.excludes, path)
+}
+
+// Included reports whether path passes the include filter. With no include
+// patterns configured, everything is included.
+func (m *Matcher) Included(path string) bool {
+	if m == nil || len(m.includes) == 0 {
+		return true
+	}
+	return anyMatch(m.includes, path)
+}
+
+// Allows reports whether path should appear in results: it must pass the
+// include filter and must not match any exclude pattern.
+func (m *Matcher) Allows(path string) bool {
+	return m.Included(path) && !m.Excluded(path)
+}
+
+func anyMatch(patterns []string, path string) bool {
+	for _, p := range patterns {
+		if ok, _ := doublestar.Match(p, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Options configures how Walk, WalkCollect, and ListDir filter results.
+type Options struct {
+	// Matcher filters which paths are included. A nil Matcher includes
+	// everything.
+	Matcher *Matcher
+
+	// Ignore is the root of a gitignore-style ignore chain. As the walk
+	// descends into a directory, its own .gitignore (if any) is layered on
+	// top via Chain.Child before matching continues. A nil Ignore ignores
+	// nothing.
+	Ignore *ignore.Chain
+
+	// Deterministic, when true, makes WalkConcurrent (and therefore Walk
+	// and WalkCollect) emit results in a stable sorted order instead of
+	// whatever order the workers happen to finish in. This costs the
+	// concurrent walker its streaming behaviour, so it's meant for tests
+	// and reproducible output rather than everyday interactive use.
+	Deterministic bool
+
+	// Print0 makes Walk terminate each printed path with '\x00' instead of
+	// '\n', so output survives filenames containing newlines when piped
+	// into xargs -0 or fzf --read0.
+	Print0 bool
+
+	// FollowSymlinks makes WalkConcurrent recurse into directories reached
+	// via a symlink, rather than treating the symlink as a leaf (the
+	// default, since an unguarded traversal can otherwise loop forever on
+	// a symlink cycle).
+	FollowSymlinks bool
+}
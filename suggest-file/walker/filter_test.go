@@ -0,0 +1,55 @@
+package walker
+
+import "testing"
+
+func TestNewMatcherRejectsInvalidPattern(t *testing.T) {
+	if _, err := NewMatcher(nil, []string{"[unterminated"}); err == nil {
+		t.Fatal("expected error for invalid exclude pattern")
+	}
+	if _, err := NewMatcher([]string{"[unterminated"}, nil); err == nil {
+		t.Fatal("expected error for invalid include pattern")
+	}
+}
+
+func TestMatcherAllows(t *testing.T) {
+	m, err := NewMatcher([]string{"**/*.go"}, []string{"**/vendor/**"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		path string
+		want bool
+	}{
+		{"main.go", true},
+		{"pkg/sub/file.go", true},
+		{"README.md", false},
+		{"vendor/lib/file.go", false},
+	}
+
+	for _, c := range cases {
+		if got := m.Allows(c.path); got != c.want {
+			t.Errorf("Allows(%q) = %v, want %v", c.path, got, c.want)
+		}
+	}
+}
+
+func TestNilMatcherAllowsEverything(t *testing.T) {
+	var m *Matcher
+	if !m.Allows("anything") {
+		t.Error("nil Matcher should allow everything")
+	}
+	if m.Excluded("anything") {
+		t.Error("nil Matcher should exclude nothing")
+	}
+}
+
+func TestMatcherIncludedWithNoIncludes(t *testing.T) {
+	m, err := NewMatcher(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Included("anything") {
+		t.Error("Matcher with no include patterns should include everything")
+	}
+}
@@ -0,0 +1,76 @@
+package walker
+
+import (
+	"sync"
+
+	"github.com/Crystalix007/cli-tools/suggest-file/ignore"
+)
+
+// dirJob is one directory awaiting a ReadDir by a worker, along with the
+// ignore chain inherited from its parent (not yet layered with its own
+// .gitignore).
+type dirJob struct {
+	dir   string
+	chain *ignore.Chain
+}
+
+// dirQueue is an unbounded FIFO queue of pending directory jobs shared by a
+// fixed pool of worker goroutines. pending counts jobs that have been pushed
+// but not yet finished (queued or currently being processed by a worker), so
+// pop can tell the difference between "no work right now, but more is
+// coming" and "the walk is done": the former blocks, the latter returns
+// ok=false.
+type dirQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	jobs    []dirJob
+	pending int
+}
+
+func newDirQueue() *dirQueue {
+	q := &dirQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// push enqueues job, marking it pending until a matching done call.
+func (q *dirQueue) push(job dirJob) {
+	q.mu.Lock()
+	q.jobs = append(q.jobs, job)
+	q.pending++
+	q.mu.Unlock()
+	q.cond.Signal()
+}
+
+// pop blocks until a job is available. ok is false once every pushed job has
+// been marked done and none remain queued, signalling the worker to exit.
+func (q *dirQueue) pop() (job dirJob, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.jobs) == 0 {
+		if q.pending == 0 {
+			return dirJob{}, false
+		}
+		q.cond.Wait()
+	}
+
+	job, q.jobs = q.jobs[0], q.jobs[1:]
+	return job, true
+}
+
+// done marks one job (previously returned by pop) as finished, waking
+// workers blocked in pop so they can either pick up newly pushed work or
+// notice the walk has completed.
+func (q *dirQueue) done() {
+	q.mu.Lock()
+	q.pending--
+	allDone := q.pending == 0
+	q.mu.Unlock()
+
+	if allDone {
+		q.cond.Broadcast()
+	} else {
+		q.cond.Signal()
+	}
+}
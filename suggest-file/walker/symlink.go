@@ -0,0 +1,45 @@
+package walker
+
+import (
+	"os"
+	"sync"
+)
+
+// dirIdentity identifies a directory by device and inode, which is stable
+// across the different paths a symlink cycle might present it under.
+type dirIdentity struct {
+	dev uint64
+	ino uint64
+}
+
+// visitedDirs tracks directories already descended into via a symlink, so a
+// cycle (e.g. a symlink pointing at one of its own ancestors) is detected
+// and broken instead of recursing forever. Safe for concurrent use.
+type visitedDirs struct {
+	mu   sync.Mutex
+	seen map[dirIdentity]bool
+}
+
+func newVisitedDirs() *visitedDirs {
+	return &visitedDirs{seen: make(map[dirIdentity]bool)}
+}
+
+// visit records info's directory identity and reports whether it had
+// already been visited. If info's identity can't be determined (a
+// platform without dirIdentityOf support), it's conservatively treated as
+// unvisited every time.
+func (v *visitedDirs) visit(info os.FileInfo) bool {
+	id, ok := dirIdentityOf(info)
+	if !ok {
+		return false
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.seen[id] {
+		return true
+	}
+	v.seen[id] = true
+	return false
+}
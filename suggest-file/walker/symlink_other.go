@@ -0,0 +1,12 @@
+//go:build !unix
+
+package walker
+
+import "os"
+
+// dirIdentityOf has no cross-platform implementation: platforms outside the
+// unix build constraint (e.g. Windows) don't expose a device/inode pair
+// through os.FileInfo.Sys(), so cycle detection is unavailable there.
+func dirIdentityOf(info os.FileInfo) (dirIdentity, bool) {
+	return dirIdentity{}, false
+}
@@ -0,0 +1,47 @@
+//go:build unix
+
+package walker
+
+import (
+	"os"
+	"testing"
+)
+
+func TestVisitedDirsDetectsCycle(t *testing.T) {
+	dir := t.TempDir()
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := newVisitedDirs()
+	if v.visit(info) {
+		t.Fatal("first visit should not report a cycle")
+	}
+	if !v.visit(info) {
+		t.Fatal("second visit of the same directory should report a cycle")
+	}
+}
+
+func TestVisitedDirsDistinctDirs(t *testing.T) {
+	a := t.TempDir()
+	b := t.TempDir()
+
+	infoA, err := os.Stat(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := newVisitedDirs()
+	if v.visit(infoA) {
+		t.Fatal("first visit of a should not report a cycle")
+	}
+	if v.visit(infoB) {
+		t.Fatal("visiting a distinct directory should not report a cycle")
+	}
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package walker
+
+import (
+	"os"
+	"syscall"
+)
+
+// dirIdentityOf extracts the (device, inode) pair from info via its
+// underlying syscall.Stat_t. ok is false if info.Sys() isn't a
+// *syscall.Stat_t.
+func dirIdentityOf(info os.FileInfo) (dirIdentity, bool) {
+	sys, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return dirIdentity{}, false
+	}
+
+	return dirIdentity{dev: uint64(sys.Dev), ino: sys.Ino}, true
+}
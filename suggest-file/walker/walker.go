@@ -3,7 +3,9 @@
 // root directory (the default Ctrl-T behaviour).
 //
 // Hidden directories (names starting with '.') are skipped, but hidden
-// files within visible directories are included.
+// files within visible directories are included. Walking fans out across
+// multiple goroutines via WalkConcurrent, since suggest-file is invoked
+// interactively and serial stat() calls dominate latency on large trees.
 package walker
 
 import (
@@ -11,6 +13,17 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// resultBufferSize and errBufferSize size the channels returned by
+// WalkConcurrent generously enough that, for realistic trees, workers
+// never block waiting on a slow consumer.
+const (
+	resultBufferSize = 4096
+	errBufferSize    = 256
 )
 
 // IsIncludableFile reports whether path should be included in results.
@@ -28,70 +41,210 @@ func IsIncludableFile(path string, mode fs.FileMode) bool {
 	return mode.IsRegular()
 }
 
-// walkFiltered is the shared implementation for Walk and WalkCollect.
-// It recursively walks root, skipping hidden directories and non-regular
-// files, and calls emit for each included path.
-func walkFiltered(root string, emit func(path string)) error {
-	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "suggest-file: %v\n", err)
-			return nil
+// WalkConcurrent walks the directory tree rooted at root, fanning directory
+// reads out across a fixed pool of runtime.NumCPU() workers draining a
+// shared queue of pending directories — the number of live goroutines stays
+// bounded by the pool size no matter how wide the tree is, since discovering
+// a subdirectory enqueues a job rather than spawning one. It skips hidden
+// directories and non-regular files, prunes directories excluded by
+// opts.Matcher, and reports unreadable directories on errs rather than
+// aborting the walk.
+//
+// Symlinked directories are treated as leaves unless opts.FollowSymlinks is
+// set, in which case they're recursed into; a (device, inode) set shared
+// across all workers detects cycles and skips them with a warning instead
+// of recursing forever.
+//
+// Results are streamed as they're found, so order is not guaranteed unless
+// opts.Deterministic is set, in which case all results are collected and
+// emitted in sorted order once the walk completes. Both channels are closed
+// once the walk finishes.
+func WalkConcurrent(root string, opts Options) (<-chan string, <-chan error) {
+	results := make(chan string, resultBufferSize)
+	errs := make(chan error, errBufferSize)
+
+	numWorkers := runtime.NumCPU()
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	visited := newVisitedDirs()
+
+	queue := newDirQueue()
+	queue.push(dirJob{dir: root, chain: opts.Ignore})
+
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
+
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				job, ok := queue.pop()
+				if !ok {
+					return
+				}
+
+				processDir(job, root, opts, visited, queue, results, errs)
+				queue.done()
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+		close(errs)
+	}()
+
+	if !opts.Deterministic {
+		return results, errs
+	}
+
+	sorted := make(chan string)
+	go func() {
+		defer close(sorted)
+
+		var paths []string
+		for path := range results {
+			paths = append(paths, path)
 		}
+		sort.Strings(paths)
 
-		// Skip hidden directories (starting with '.') other than the root itself.
-		if d.IsDir() && path != root && d.Name()[0] == '.' {
-			return fs.SkipDir
+		for _, path := range paths {
+			sorted <- path
 		}
+	}()
 
-		if d.IsDir() {
-			return nil
+	return sorted, errs
+}
+
+// processDir reads one directory's entries, emits matching files to results,
+// and enqueues its matching subdirectories onto queue for a worker to pick
+// up in turn.
+func processDir(job dirJob, root string, opts Options, visited *visitedDirs, queue *dirQueue, results chan<- string, errs chan<- error) {
+	dir := job.dir
+	chain := job.chain.Child(dir)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		wrapped := fmt.Errorf("reading directory %q: %w", dir, err)
+		fmt.Fprintf(os.Stderr, "suggest-file: %v\n", wrapped)
+		errs <- wrapped
+		return
+	}
+
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+
+		isDir := e.IsDir()
+
+		if !isDir && opts.FollowSymlinks && e.Type()&fs.ModeSymlink != 0 {
+			info, err := os.Stat(path)
+			if err == nil && info.IsDir() {
+				if visited.visit(info) {
+					fmt.Fprintf(os.Stderr, "suggest-file: skipping %q: symlink cycle detected\n", path)
+					continue
+				}
+				isDir = true
+			}
 		}
 
-		if IsIncludableFile(path, d.Type()) {
-			emit(path)
+		if isDir {
+			// Skip hidden directories (starting with '.') other than the root itself.
+			if path != root && e.Name()[0] == '.' {
+				continue
+			}
+
+			if opts.Matcher.Excluded(path) || chain.Match(path, true) {
+				continue
+			}
+
+			queue.push(dirJob{dir: path, chain: chain})
+			continue
 		}
 
-		return nil
-	})
+		if IsIncludableFile(path, e.Type()) && opts.Matcher.Allows(path) && !chain.Match(path, false) {
+			results <- path
+		}
+	}
 }
 
 // Walk recursively walks the directory tree rooted at root and prints
-// every regular file path to stdout, one per line. Directories that
-// cannot be read (e.g. due to permission errors) are skipped with a
-// warning on stderr rather than aborting.
-func Walk(root string) error {
-	return walkFiltered(root, func(path string) {
-		fmt.Println(path)
-	})
+// every regular file path to stdout, terminated by '\n' or, if opts.Print0
+// is set, '\x00'. Directories that cannot be read (e.g. due to permission
+// errors) are skipped with a warning on stderr rather than aborting.
+func Walk(root string, opts Options) error {
+	terminator := "\n"
+	if opts.Print0 {
+		terminator = "\x00"
+	}
+
+	results, errs := WalkConcurrent(root, opts)
+
+	for results != nil || errs != nil {
+		select {
+		case path, ok := <-results:
+			if !ok {
+				results = nil
+				continue
+			}
+			fmt.Fprintf(os.Stdout, "%s%s", path, terminator)
+		case _, ok := <-errs:
+			if !ok {
+				errs = nil
+			}
+			// Errors are already reported on stderr by WalkConcurrent.
+		}
+	}
+
+	return nil
 }
 
 // WalkCollect recursively walks the directory tree rooted at root and
 // returns all regular file paths as a slice. Like Walk, hidden directories
 // are skipped, but hidden files within visible directories are included.
 // Errors reading individual entries are reported on stderr and skipped.
-func WalkCollect(root string) ([]string, error) {
-	var results []string
-	err := walkFiltered(root, func(path string) {
-		results = append(results, path)
-	})
-	return results, err
+func WalkCollect(root string, opts Options) ([]string, error) {
+	results, errs := WalkConcurrent(root, opts)
+
+	var paths []string
+	for results != nil || errs != nil {
+		select {
+		case path, ok := <-results:
+			if !ok {
+				results = nil
+				continue
+			}
+			paths = append(paths, path)
+		case _, ok := <-errs:
+			if !ok {
+				errs = nil
+			}
+			// Errors are already reported on stderr by WalkConcurrent.
+		}
+	}
+
+	return paths, nil
 }
 
 // ListDir lists files directly within dir (one level deep, non-recursive).
 // Only regular files and symlinks that resolve to files are included.
 // Hidden entries are included (consistent with explicit directory listing).
-func ListDir(dir string) ([]string, error) {
+func ListDir(dir string, opts Options) ([]string, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("listing %q: %w", dir, err)
 	}
 
+	chain := opts.Ignore.Child(dir)
+
 	var results []string
 
 	for _, e := range entries {
 		full := filepath.Join(dir, e.Name())
 
-		if IsIncludableFile(full, e.Type()) {
+		if IsIncludableFile(full, e.Type()) && opts.Matcher.Allows(full) && !chain.Match(full, e.IsDir()) {
 			results = append(results, full)
 		}
 	}
@@ -0,0 +1,128 @@
+package walker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// buildTree creates a synthetic directory tree for tests and benchmarks:
+// depth directories deep, with width regular files in every directory and
+// width subdirectories in every directory above the deepest level.
+func buildTree(t testing.TB, root string, depth, width int) {
+	t.Helper()
+
+	var build func(dir string, depth int)
+	build = func(dir string, depth int) {
+		for i := 0; i < width; i++ {
+			file := filepath.Join(dir, fmt.Sprintf("file%d.txt", i))
+			if err := os.WriteFile(file, nil, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if depth == 0 {
+			return
+		}
+		for i := 0; i < width; i++ {
+			sub := filepath.Join(dir, fmt.Sprintf("dir%d", i))
+			if err := os.Mkdir(sub, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			build(sub, depth-1)
+		}
+	}
+	build(root, depth)
+}
+
+func collect(t testing.TB, root string, opts Options) []string {
+	t.Helper()
+	paths, err := WalkCollect(root, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return paths
+}
+
+func TestWalkCollectFindsAllFiles(t *testing.T) {
+	root := t.TempDir()
+	buildTree(t, root, 2, 3)
+
+	paths := collect(t, root, Options{})
+
+	// depth=2, width=3: each of the 1+3+9=13 directories holds 3 files.
+	want := 13 * 3
+	if len(paths) != want {
+		t.Fatalf("got %d files, want %d", len(paths), want)
+	}
+}
+
+func TestWalkCollectSkipsHiddenDirs(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".hidden"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".hidden", "secret.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "visible.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := collect(t, root, Options{})
+	if len(paths) != 1 || filepath.Base(paths[0]) != "visible.txt" {
+		t.Fatalf("got %v, want only visible.txt", paths)
+	}
+}
+
+func TestWalkCollectPrunesExcludedDirs(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "vendor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "vendor", "lib.go"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "main.go"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	matcher, err := NewMatcher(nil, []string{"**/vendor/**", "**/vendor"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := collect(t, root, Options{Matcher: matcher})
+	if len(paths) != 1 || filepath.Base(paths[0]) != "main.go" {
+		t.Fatalf("got %v, want only main.go", paths)
+	}
+}
+
+func TestWalkConcurrentDeterministicIsSorted(t *testing.T) {
+	root := t.TempDir()
+	buildTree(t, root, 2, 3)
+
+	paths := collect(t, root, Options{Deterministic: true})
+
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+
+	for i := range paths {
+		if paths[i] != sorted[i] {
+			t.Fatalf("results not sorted: %v", paths)
+		}
+	}
+}
+
+func BenchmarkWalkConcurrent(b *testing.B) {
+	root := b.TempDir()
+	buildTree(b, root, 3, 6)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := WalkCollect(root, Options{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}